@@ -0,0 +1,59 @@
+// Package address provides a parsed representation of a PCI address so the
+// rest of the API surface does not have to deal with raw BDF/DBSF strings.
+package address
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// addressPattern matches both the short BDF form (bus:slot.function, e.g.
+// "00:1f.2") and the full DBSF form (domain:bus:slot.function, e.g.
+// "0000:00:1f.2"). The domain is optional and defaults to "0000".
+var addressPattern = regexp.MustCompile(
+	`^(?:([0-9a-fA-F]{4}):)?([0-9a-fA-F]{2}):([0-9a-fA-F]{2})\.([0-7])$`,
+)
+
+// Address is a structured, canonical representation of a PCI device
+// address in Domain:Bus:Slot.Function (DBSF) form.
+type Address struct {
+	Domain   string
+	Bus      string
+	Slot     string
+	Function string
+}
+
+// FromString parses a PCI address given in either BDF ("00:1f.2") or DBSF
+// ("0000:00:1f.2") form and returns its structured representation. It
+// returns an error if addr does not match either form, so malformed
+// addresses are rejected with a clear error instead of silently producing
+// colliding resource names downstream.
+func FromString(addr string) (Address, error) {
+	matches := addressPattern.FindStringSubmatch(addr)
+	if matches == nil {
+		return Address{}, fmt.Errorf("invalid PCI address %q: expected BDF (bb:ss.f) or DBSF (dddd:bb:ss.f) form", addr)
+	}
+	domain := strings.ToLower(matches[1])
+	if domain == "" {
+		domain = "0000"
+	}
+	return Address{
+		Domain:   domain,
+		Bus:      strings.ToLower(matches[2]),
+		Slot:     strings.ToLower(matches[3]),
+		Function: strings.ToLower(matches[4]),
+	}, nil
+}
+
+// String returns the canonical DBSF form of the address, e.g. "0000:00:1f.2".
+func (a Address) String() string {
+	return fmt.Sprintf("%s:%s:%s.%s", a.Domain, a.Bus, a.Slot, a.Function)
+}
+
+// DNSSafe returns a form of the address suitable for embedding in a
+// Kubernetes resource name: colons and the dot are stripped, leaving a
+// bare hex string, e.g. "0000001f2".
+func (a Address) DNSSafe() string {
+	return a.Domain + a.Bus + a.Slot + a.Function
+}
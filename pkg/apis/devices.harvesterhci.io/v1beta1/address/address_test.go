@@ -0,0 +1,88 @@
+package address
+
+import "testing"
+
+func TestFromString(t *testing.T) {
+	tests := []struct {
+		name    string
+		addr    string
+		want    Address
+		wantErr bool
+	}{
+		{
+			name: "BDF form",
+			addr: "00:1f.2",
+			want: Address{Domain: "0000", Bus: "00", Slot: "1f", Function: "2"},
+		},
+		{
+			name: "DBSF form",
+			addr: "0000:00:1f.2",
+			want: Address{Domain: "0000", Bus: "00", Slot: "1f", Function: "2"},
+		},
+		{
+			name: "non-zero domain",
+			addr: "0001:02:03.4",
+			want: Address{Domain: "0001", Bus: "02", Slot: "03", Function: "4"},
+		},
+		{
+			name: "mixed-case BDF normalizes to lowercase",
+			addr: "00:1F.2",
+			want: Address{Domain: "0000", Bus: "00", Slot: "1f", Function: "2"},
+		},
+		{
+			name: "mixed-case DBSF normalizes to lowercase",
+			addr: "0000:00:1F.2",
+			want: Address{Domain: "0000", Bus: "00", Slot: "1f", Function: "2"},
+		},
+		{
+			name:    "garbage input",
+			addr:    "not-a-pci-address",
+			wantErr: true,
+		},
+		{
+			name:    "empty input",
+			addr:    "",
+			wantErr: true,
+		},
+		{
+			name:    "function out of range",
+			addr:    "00:1f.8",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromString(tt.addr)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("FromString(%q) = %+v, want error", tt.addr, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FromString(%q) returned unexpected error: %v", tt.addr, err)
+			}
+			if got != tt.want {
+				t.Fatalf("FromString(%q) = %+v, want %+v", tt.addr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromStringCaseInsensitiveCollision(t *testing.T) {
+	upper, err := FromString("0000:00:1F.2")
+	if err != nil {
+		t.Fatalf("FromString returned error: %v", err)
+	}
+	lower, err := FromString("0000:00:1f.2")
+	if err != nil {
+		t.Fatalf("FromString returned error: %v", err)
+	}
+	if upper.String() != lower.String() {
+		t.Fatalf("addresses differing only by case must canonicalize identically: %q != %q", upper.String(), lower.String())
+	}
+	if upper.DNSSafe() != lower.DNSSafe() {
+		t.Fatalf("addresses differing only by case must produce the same DNS-safe form: %q != %q", upper.DNSSafe(), lower.DNSSafe())
+	}
+}
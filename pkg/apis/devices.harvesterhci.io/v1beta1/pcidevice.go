@@ -2,13 +2,18 @@ package v1beta1
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/jaypipes/ghw/pkg/pci"
 	"github.com/jaypipes/ghw/pkg/util"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/votdev/pcidevices/pkg/apis/devices.harvesterhci.io/v1beta1/address"
 )
 
 const (
@@ -35,7 +40,16 @@ type PCIDevice struct {
 
 // PCIDeviceStatus defines the observed state of PCIDevice
 type PCIDeviceStatus struct {
-	Address           string `json:"address"`
+	// Address is the canonical DBSF (domain:bus:slot.function) form of the
+	// device's PCI address, e.g. "0000:00:1f.2".
+	Address string `json:"address"`
+	// Domain, Bus, Slot and Function are the individually addressable
+	// components of Address, broken out so they can be used as label
+	// selectors (e.g. selecting every device on a given slot).
+	Domain            string `json:"domain"`
+	Bus               string `json:"bus"`
+	Slot              string `json:"slot"`
+	Function          string `json:"function"`
 	VendorID          string `json:"vendorId"`
 	DeviceID          string `json:"deviceId"`
 	ClassID           string `json:"classId"`
@@ -44,6 +58,14 @@ type PCIDeviceStatus struct {
 	ResourceName      string `json:"resourceName"`
 	Description       string `json:"description"`
 	KernelDriverInUse string `json:"kernelDriverInUse,omitempty"`
+	// NUMANode is the NUMA node the device is attached to, read from the
+	// device's sysfs numa_node file. It is nil when the host has no NUMA
+	// topology or the device did not report one (sysfs value -1).
+	NUMANode *int `json:"numaNode,omitempty"`
+	// IOMMUGroupSiblings lists the PCI addresses of the other devices in
+	// this device's IOMMU group, so a caller can tell without an extra
+	// lookup whether claiming this device also requires its siblings.
+	IOMMUGroupSiblings []string `json:"iommuGroupSiblings,omitempty"`
 }
 
 func description(dev *pci.Device) string {
@@ -91,7 +113,13 @@ func extractVendorNameFromBrackets(vendorName string) string {
 	return strip(preSlash)
 }
 
-func resourceName(dev *pci.Device) string {
+// defaultResourceNamePrefix derives the "<vendor>.com" resource name
+// prefix from dev's vendor name, preferring the bracketed short form
+// (e.g. "Advanced Micro Devices, Inc. [AMD/ATI]" -> "amd.com") when
+// present. This is the one place that vendor-name-to-prefix logic lives;
+// both the built-in heuristic and the PCIDeviceClassConfig template
+// fallback in renderResourceName route through it.
+func defaultResourceNamePrefix(dev *pci.Device) string {
 	var vendorBase string
 	// if vendor name has a '[name]', then use that
 	if strings.Contains(dev.Vendor.Name, "[") {
@@ -99,9 +127,13 @@ func resourceName(dev *pci.Device) string {
 	} else {
 		vendorBase = strip(strings.Split(dev.Vendor.Name, " ")[0])
 	}
-	vendorCleaned := strings.ToLower(
+	return strings.ToLower(
 		strings.ReplaceAll(vendorBase, " ", ""),
 	) + ".com"
+}
+
+func resourceName(dev *pci.Device) string {
+	vendorCleaned := defaultResourceNamePrefix(dev)
 	if dev.Product.Name != util.UNKNOWN {
 		productCleaned := strings.TrimSpace(dev.Product.Name)
 		productCleaned = strings.ToUpper(productCleaned)
@@ -117,55 +149,129 @@ func resourceName(dev *pci.Device) string {
 	return fmt.Sprintf("%s/%s", vendorCleaned, dev.Product.ID)
 }
 
-func (status *PCIDeviceStatus) Update(dev *pci.Device, hostname string, iommuGroups map[string]int) {
-	status.Address = dev.Address
+func (status *PCIDeviceStatus) Update(dev *pci.Device, hostname string, iommuGroups map[string]int, excludeTopology bool, classConfig *PCIDeviceClassConfig) error {
+	addr, err := address.FromString(dev.Address)
+	if err != nil {
+		return fmt.Errorf("updating status for device on %s: %w", hostname, err)
+	}
+	status.Address = addr.String()
+	status.Domain = addr.Domain
+	status.Bus = addr.Bus
+	status.Slot = addr.Slot
+	status.Function = addr.Function
 	status.VendorID = dev.Vendor.ID
 	status.DeviceID = dev.Product.ID
 	status.ClassID = fmt.Sprintf("%s%s", dev.Class.ID, dev.Subclass.ID)
 	// Generate the ResourceName field, this is used by KubeVirt to schedule the VM to the node
-	status.ResourceName = resourceName(dev)
+	status.ResourceName = templatedResourceName(dev, classConfig)
 	status.Description = description(dev)
 	group, ok := iommuGroups[dev.Address]
 	if ok {
 		status.IOMMUGroup = strconv.Itoa(group)
+		status.IOMMUGroupSiblings = iommuGroupSiblings(iommuGroups, dev.Address, group)
 	}
 	status.KernelDriverInUse = dev.Driver
 	status.NodeName = hostname
+	if excludeTopology {
+		status.NUMANode = nil
+	} else {
+		status.NUMANode = numaNodeForAddress(status.Address)
+	}
+	return nil
+}
+
+// iommuGroupSiblings returns the other PCI addresses sharing group,
+// excluding selfAddress, canonicalized to the same DBSF form as
+// Status.Address so callers can key off either field interchangeably. A
+// sibling whose raw address fails to parse is skipped rather than reported
+// under a mismatched form.
+func iommuGroupSiblings(iommuGroups map[string]int, selfAddress string, group int) []string {
+	var siblings []string
+	for addr, g := range iommuGroups {
+		if g != group || addr == selfAddress {
+			continue
+		}
+		parsed, err := address.FromString(addr)
+		if err != nil {
+			continue
+		}
+		siblings = append(siblings, parsed.String())
+	}
+	sort.Strings(siblings)
+	return siblings
+}
+
+// numaNodeForAddress reads the NUMA node a PCI device is attached to from
+// sysfs. A device with no NUMA affinity (or a host without NUMA support)
+// reports -1, which is normalized to nil so the KubeVirt device-plugin
+// registration does not falsely advertise node 0.
+func numaNodeForAddress(address string) *int {
+	raw, err := os.ReadFile(filepath.Join(sysfsPCIDevicesRoot, address, "numa_node"))
+	if err != nil {
+		return nil
+	}
+	node, err := strconv.Atoi(strings.TrimSpace(string(raw)))
+	if err != nil || node < 0 {
+		return nil
+	}
+	return &node
 }
 
 type PCIDeviceSpec struct {
 }
 
-func PCIDeviceNameForHostname(address string, hostname string) string {
-	addrDNSsafe := strings.ReplaceAll(strings.ReplaceAll(address, ":", ""), ".", "")
+// PCIDeviceNameForHostname builds the Kubernetes object name for a PCIDevice
+// from its PCI address and hostname. addr may be given in either BDF or
+// DBSF form; a malformed address is rejected rather than silently producing
+// a colliding resource name.
+func PCIDeviceNameForHostname(addr string, hostname string) (string, error) {
+	parsed, err := address.FromString(addr)
+	if err != nil {
+		return "", fmt.Errorf("naming PCI device on %s: %w", hostname, err)
+	}
 	return fmt.Sprintf(
 		"%s-%s",
 		hostname,
-		addrDNSsafe,
-	)
+		parsed.DNSSafe(),
+	), nil
 }
 
-func NewPCIDeviceForHostname(dev *pci.Device, hostname string) PCIDevice {
-	name := PCIDeviceNameForHostname(dev.Address, hostname)
+// NewPCIDeviceForHostname builds the PCIDevice object for dev, populating
+// its Status (including NUMA and IOMMU group topology) via
+// PCIDeviceStatus.Update. When dev is a virtual function and parent is
+// non-nil (the PCIDevice already created for its physical function), the
+// new object back-references the PF via both an owner reference and the
+// PCIDeviceParentAddress annotation, so a PCIDeviceVFPool can be resolved
+// to its member VFs without a sysfs walk.
+func NewPCIDeviceForHostname(dev *pci.Device, hostname string, classConfig *PCIDeviceClassConfig, parent *PCIDevice, iommuGroups map[string]int, excludeTopology bool) (PCIDevice, error) {
+	name, err := PCIDeviceNameForHostname(dev.Address, hostname)
+	if err != nil {
+		return PCIDevice{}, err
+	}
+	annotations := map[string]string{
+		PciDeviceDriver: dev.Driver,
+	}
+	var ownerRefs []metav1.OwnerReference
+	if parent != nil {
+		annotations[PCIDeviceParentAddress] = parent.Status.Address
+		ownerRefs = append(ownerRefs, metav1.OwnerReference{
+			APIVersion: SchemeGroupVersion.String(),
+			Kind:       "PCIDevice",
+			Name:       parent.Name,
+			UID:        parent.UID,
+		})
+	}
 	pciDevice := PCIDevice{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: name,
-			Annotations: map[string]string{
-				PciDeviceDriver: dev.Driver,
-			},
-		},
-		Status: PCIDeviceStatus{
-			Address:           dev.Address,
-			VendorID:          dev.Vendor.ID,
-			DeviceID:          dev.Product.ID,
-			ClassID:           fmt.Sprintf("%s%s", dev.Class.ID, dev.Subclass.ID),
-			NodeName:          hostname,
-			ResourceName:      resourceName(dev),
-			Description:       description(dev),
-			KernelDriverInUse: dev.Driver,
+			Name:            name,
+			Annotations:     annotations,
+			OwnerReferences: ownerRefs,
 		},
 	}
-	return pciDevice
+	if err := pciDevice.Status.Update(dev, hostname, iommuGroups, excludeTopology, classConfig); err != nil {
+		return PCIDevice{}, err
+	}
+	return pciDevice, nil
 }
 
 // if plugin name is going to exceed 108 chars due to 108 char limit on socket length
@@ -0,0 +1,40 @@
+package v1beta1
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNumaNodeForAddress(t *testing.T) {
+	root := withFakeSysfs(t)
+
+	t.Run("reports a valid NUMA node", func(t *testing.T) {
+		dir := filepath.Join(root, "0000:3b:00.0")
+		mustMkdirAll(t, dir)
+		if err := os.WriteFile(filepath.Join(dir, "numa_node"), []byte("1\n"), 0644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		got := numaNodeForAddress("0000:3b:00.0")
+		if got == nil || *got != 1 {
+			t.Fatalf("numaNodeForAddress() = %v, want pointer to 1", got)
+		}
+	})
+
+	t.Run("normalizes -1 (no NUMA affinity) to nil", func(t *testing.T) {
+		dir := filepath.Join(root, "0000:3b:00.1")
+		mustMkdirAll(t, dir)
+		if err := os.WriteFile(filepath.Join(dir, "numa_node"), []byte("-1\n"), 0644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		if got := numaNodeForAddress("0000:3b:00.1"); got != nil {
+			t.Fatalf("numaNodeForAddress() = %v, want nil", *got)
+		}
+	})
+
+	t.Run("missing numa_node file returns nil", func(t *testing.T) {
+		if got := numaNodeForAddress("0000:99:00.0"); got != nil {
+			t.Fatalf("numaNodeForAddress() = %v, want nil", *got)
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/votdev/pcidevices/pkg/apis/devices.harvesterhci.io/v1beta1/address"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+
+// PCIDeviceClaim is the Schema for the pcideviceclaims API
+type PCIDeviceClaim struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PCIDeviceClaimSpec   `json:"spec,omitempty"`
+	Status PCIDeviceClaimStatus `json:"status,omitempty"`
+}
+
+// PCIDeviceClaimSpec defines the desired state of PCIDeviceClaim
+type PCIDeviceClaimSpec struct {
+	Address  string `json:"address"`
+	NodeName string `json:"nodeName"`
+	UserName string `json:"userName"`
+	// ExcludeTopology opts this device out of NUMA node advertisement,
+	// for pools that intentionally aggregate devices spanning multiple
+	// sockets where a single NUMA affinity would be misleading.
+	ExcludeTopology bool `json:"excludeTopology,omitempty"`
+	// IOMMUGroupIsolated acknowledges that this device's IOMMU group
+	// siblings are intentionally left unclaimed, bypassing the "whole
+	// group or nothing" validation VFIO passthrough otherwise requires.
+	IOMMUGroupIsolated bool `json:"iommuGroupIsolated,omitempty"`
+}
+
+// PCIDeviceClaimStatus defines the observed state of PCIDeviceClaim
+type PCIDeviceClaimStatus struct {
+	NodeName           string `json:"nodeName"`
+	KernelDriverInUse  string `json:"kernelDriverInUse,omitempty"`
+	PassthroughEnabled bool   `json:"passthroughEnabled"`
+}
+
+// ExcludeTopologyFromClaims returns a lookup, keyed by canonical DBSF PCI
+// address, of whether a device has been claimed with ExcludeTopology set.
+// It is meant to be passed as BuildPCIDevicesForHostname's excludeTopology
+// argument so a scan honors the exclusion recorded on each device's
+// PCIDeviceClaim. A claim whose address fails to parse is skipped rather
+// than failing the whole lookup.
+func ExcludeTopologyFromClaims(claims []PCIDeviceClaim) func(address string) bool {
+	excluded := make(map[string]bool, len(claims))
+	for _, claim := range claims {
+		if !claim.Spec.ExcludeTopology {
+			continue
+		}
+		parsed, err := address.FromString(claim.Spec.Address)
+		if err != nil {
+			continue
+		}
+		excluded[parsed.String()] = true
+	}
+	return func(addr string) bool {
+		parsed, err := address.FromString(addr)
+		if err != nil {
+			return false
+		}
+		return excluded[parsed.String()]
+	}
+}
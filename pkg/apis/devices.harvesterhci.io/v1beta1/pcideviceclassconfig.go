@@ -0,0 +1,162 @@
+package v1beta1
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/jaypipes/ghw/pkg/pci"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+
+// PCIDeviceClassConfig is the Schema for the pcideviceclassconfigs API. It
+// lets operators override the resource name the controller computes for
+// devices matching a vendor/device/class glob, instead of relying on the
+// built-in vendor-name-plus-product heuristic in resourceName().
+type PCIDeviceClassConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PCIDeviceClassConfigSpec `json:"spec,omitempty"`
+}
+
+// PCIDeviceClassConfigSpec defines the desired state of PCIDeviceClassConfig
+type PCIDeviceClassConfigSpec struct {
+	Rules []PCIDeviceClassRule `json:"rules"`
+}
+
+// PCIDeviceClassRule matches a set of devices by glob and supplies the
+// template used to render their resource name.
+type PCIDeviceClassRule struct {
+	// VendorID, DeviceID and ClassID are glob patterns (as accepted by
+	// path.Match) matched against the device's hex IDs, e.g. "10de*".
+	// An empty pattern matches everything.
+	VendorID string `json:"vendorId,omitempty"`
+	DeviceID string `json:"deviceId,omitempty"`
+	ClassID  string `json:"classId,omitempty"`
+
+	// Template is a text/template that renders the resource name's
+	// suffix (the part after ResourceNamePrefix + "/"). It is executed
+	// against a ResourceNameData value and has the strip, upper, trimTo
+	// and id helpers available.
+	Template string `json:"template"`
+
+	// ResourceNamePrefix defaults to "<vendor>.com" when unset.
+	ResourceNamePrefix string `json:"resourceNamePrefix,omitempty"`
+
+	// UUIDSuffixLength, when non-zero, appends the last UUIDSuffixLength
+	// hex digits of the device's PCI address (separators removed) to the
+	// rendered name to disambiguate otherwise-identical names, in the
+	// manner of --volume-name-uuid-length in external-provisioner. The
+	// suffix is derived from the address; no UUID is generated.
+	UUIDSuffixLength int `json:"uuidSuffixLength,omitempty"`
+}
+
+// ResourceNameData is the value a PCIDeviceClassRule.Template is executed
+// against.
+type ResourceNameData struct {
+	VendorID    string
+	DeviceID    string
+	ClassID     string
+	VendorName  string
+	ProductName string
+}
+
+var templateFuncs = template.FuncMap{
+	"strip":  strip,
+	"upper":  strings.ToUpper,
+	"trimTo": trimTo,
+	"id":     func(d ResourceNameData) string { return d.DeviceID },
+}
+
+// trimTo truncates s to at most n runes, so a Go template can bound a
+// field's contribution to the final socket name via `{{ trimTo 20 .ProductName }}`.
+func trimTo(n int, s string) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
+
+// matches reports whether rule applies to dev, using glob matching on the
+// vendor, device and class IDs. An empty pattern matches any value.
+func (rule PCIDeviceClassRule) matches(dev *pci.Device) bool {
+	classID := fmt.Sprintf("%s%s", dev.Class.ID, dev.Subclass.ID)
+	return globMatches(rule.VendorID, dev.Vendor.ID) &&
+		globMatches(rule.DeviceID, dev.Product.ID) &&
+		globMatches(rule.ClassID, classID)
+}
+
+func globMatches(pattern, value string) bool {
+	if pattern == "" {
+		return true
+	}
+	ok, err := path.Match(pattern, value)
+	return err == nil && ok
+}
+
+// templatedResourceName renders dev's resource name using the first
+// matching rule in config, falling back to the built-in heuristic when
+// config is nil, has no matching rule, or the template fails to render.
+// The result is re-trimmed to the socket file name limit exactly like the
+// fallback path, so a verbose template cannot produce an unusable socket.
+func templatedResourceName(dev *pci.Device, config *PCIDeviceClassConfig) string {
+	if config == nil {
+		return resourceName(dev)
+	}
+	for _, rule := range config.Spec.Rules {
+		if !rule.matches(dev) {
+			continue
+		}
+		rendered, err := renderResourceName(dev, rule)
+		if err != nil {
+			return resourceName(dev)
+		}
+		return rendered
+	}
+	return resourceName(dev)
+}
+
+func renderResourceName(dev *pci.Device, rule PCIDeviceClassRule) (string, error) {
+	tmpl, err := template.New("resourceName").Funcs(templateFuncs).Parse(rule.Template)
+	if err != nil {
+		return "", fmt.Errorf("parsing resource name template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, ResourceNameData{
+		VendorID:    dev.Vendor.ID,
+		DeviceID:    dev.Product.ID,
+		ClassID:     fmt.Sprintf("%s%s", dev.Class.ID, dev.Subclass.ID),
+		VendorName:  dev.Vendor.Name,
+		ProductName: dev.Product.Name,
+	}); err != nil {
+		return "", fmt.Errorf("rendering resource name template: %w", err)
+	}
+	suffix := buf.String()
+	if rule.UUIDSuffixLength > 0 {
+		// dev.Address (the device's own PCI address) is unique per
+		// instance, unlike VendorID/DeviceID which are identical across
+		// every card of the same model - that's what actually
+		// disambiguates two otherwise-identical devices. The trailing
+		// digits (bus, slot, function) are kept, since the leading domain
+		// is the same for nearly every device on a host.
+		addrDigits := strip(dev.Address)
+		if len(addrDigits) > rule.UUIDSuffixLength {
+			addrDigits = addrDigits[len(addrDigits)-rule.UUIDSuffixLength:]
+		}
+		suffix = fmt.Sprintf("%s-%s", suffix, addrDigits)
+	}
+	prefix := rule.ResourceNamePrefix
+	if prefix == "" {
+		prefix = defaultResourceNamePrefix(dev)
+	}
+	return trimResourceNameIfNeeded(prefix, suffix, dev.Product.ID), nil
+}
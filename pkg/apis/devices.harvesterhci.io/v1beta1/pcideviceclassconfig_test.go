@@ -0,0 +1,140 @@
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/jaypipes/ghw/pkg/pci"
+	"github.com/jaypipes/pcidb"
+)
+
+func testDevice() *pci.Device {
+	return &pci.Device{
+		Address:  "0000:3b:00.0",
+		Vendor:   &pcidb.Vendor{ID: "10de", Name: "NVIDIA Corporation"},
+		Product:  &pcidb.Product{ID: "1eb8", Name: "TU104GL [Tesla T4]"},
+		Class:    &pcidb.Class{ID: "03", Name: "Display controller"},
+		Subclass: &pcidb.Subclass{ID: "02", Name: "3D controller"},
+	}
+}
+
+func TestPCIDeviceClassRuleMatches(t *testing.T) {
+	dev := testDevice()
+	tests := []struct {
+		name string
+		rule PCIDeviceClassRule
+		want bool
+	}{
+		{name: "all empty matches anything", rule: PCIDeviceClassRule{}, want: true},
+		{name: "exact vendor/device/class matches", rule: PCIDeviceClassRule{VendorID: "10de", DeviceID: "1eb8", ClassID: "0302"}, want: true},
+		{name: "vendor glob matches", rule: PCIDeviceClassRule{VendorID: "10*"}, want: true},
+		{name: "vendor mismatch", rule: PCIDeviceClassRule{VendorID: "8086"}, want: false},
+		{name: "device mismatch", rule: PCIDeviceClassRule{DeviceID: "0000"}, want: false},
+		{name: "class mismatch", rule: PCIDeviceClassRule{ClassID: "0200"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.matches(dev); got != tt.want {
+				t.Fatalf("matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplatedResourceNameFallback(t *testing.T) {
+	dev := testDevice()
+	want := resourceName(dev)
+
+	t.Run("nil config falls back to built-in heuristic", func(t *testing.T) {
+		if got := templatedResourceName(dev, nil); got != want {
+			t.Fatalf("templatedResourceName(nil) = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("no matching rule falls back to built-in heuristic", func(t *testing.T) {
+		config := &PCIDeviceClassConfig{Spec: PCIDeviceClassConfigSpec{
+			Rules: []PCIDeviceClassRule{{VendorID: "8086", Template: "{{ .VendorID }}"}},
+		}}
+		if got := templatedResourceName(dev, config); got != want {
+			t.Fatalf("templatedResourceName() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("invalid template falls back to built-in heuristic", func(t *testing.T) {
+		config := &PCIDeviceClassConfig{Spec: PCIDeviceClassConfigSpec{
+			Rules: []PCIDeviceClassRule{{Template: "{{ .NoSuchField }}"}},
+		}}
+		if got := templatedResourceName(dev, config); got != want {
+			t.Fatalf("templatedResourceName() = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestTemplatedResourceNameRulePrecedence(t *testing.T) {
+	dev := testDevice()
+	config := &PCIDeviceClassConfig{Spec: PCIDeviceClassConfigSpec{
+		Rules: []PCIDeviceClassRule{
+			{VendorID: "8086", Template: "WRONG_VENDOR"},
+			{VendorID: "10de", Template: "T4", ResourceNamePrefix: "nvidia.com"},
+			{Template: "CATCH_ALL"},
+		},
+	}}
+	got := templatedResourceName(dev, config)
+	want := "nvidia.com/T4"
+	if got != want {
+		t.Fatalf("templatedResourceName() = %q, want %q (first matching rule should win)", got, want)
+	}
+}
+
+func TestTemplatedResourceNamePrefixDefaultsToVendor(t *testing.T) {
+	dev := testDevice()
+	config := &PCIDeviceClassConfig{Spec: PCIDeviceClassConfigSpec{
+		Rules: []PCIDeviceClassRule{{VendorID: "10de", Template: "T4"}},
+	}}
+	got := templatedResourceName(dev, config)
+	want := defaultResourceNamePrefix(dev) + "/T4"
+	if got != want {
+		t.Fatalf("templatedResourceName() = %q, want %q", got, want)
+	}
+}
+
+func TestTemplatedResourceNameUUIDSuffixDisambiguates(t *testing.T) {
+	devA := testDevice()
+	devA.Address = "0000:3b:00.0"
+	devB := testDevice()
+	devB.Address = "0000:5e:00.0"
+
+	config := &PCIDeviceClassConfig{Spec: PCIDeviceClassConfigSpec{
+		Rules: []PCIDeviceClassRule{{Template: "T4", UUIDSuffixLength: 6}},
+	}}
+
+	nameA := templatedResourceName(devA, config)
+	nameB := templatedResourceName(devB, config)
+	if nameA == nameB {
+		t.Fatalf("two devices of the same model produced identical resource names with UUIDSuffixLength set: %q", nameA)
+	}
+
+	// Same device, rendered twice, must be stable.
+	if again := templatedResourceName(devA, config); again != nameA {
+		t.Fatalf("templatedResourceName() is not stable across calls: %q != %q", again, nameA)
+	}
+}
+
+func TestGlobMatches(t *testing.T) {
+	tests := []struct {
+		pattern string
+		value   string
+		want    bool
+	}{
+		{pattern: "", value: "10de", want: true},
+		{pattern: "10de", value: "10de", want: true},
+		{pattern: "10de", value: "8086", want: false},
+		{pattern: "10*", value: "10de", want: true},
+		{pattern: "80*", value: "10de", want: false},
+		{pattern: "[", value: "10de", want: false},
+	}
+	for _, tt := range tests {
+		if got := globMatches(tt.pattern, tt.value); got != tt.want {
+			t.Fatalf("globMatches(%q, %q) = %v, want %v", tt.pattern, tt.value, got, tt.want)
+		}
+	}
+}
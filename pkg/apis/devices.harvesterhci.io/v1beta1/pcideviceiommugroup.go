@@ -0,0 +1,82 @@
+package v1beta1
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+
+// PCIDeviceIOMMUGroup is the Schema for the pcideviceiommugroups API. There
+// is one instance per host+IOMMU-group pair, listing every PCIDevice that
+// shares the group so VFIO's "whole group or nothing" constraint can be
+// enforced at admission time instead of failing silently at VM start.
+type PCIDeviceIOMMUGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PCIDeviceIOMMUGroupSpec   `json:"spec,omitempty"`
+	Status PCIDeviceIOMMUGroupStatus `json:"status,omitempty"`
+}
+
+// PCIDeviceIOMMUGroupSpec defines the desired state of PCIDeviceIOMMUGroup
+type PCIDeviceIOMMUGroupSpec struct {
+	NodeName string `json:"nodeName"`
+	GroupID  string `json:"groupId"`
+}
+
+// PCIDeviceIOMMUGroupStatus defines the observed state of PCIDeviceIOMMUGroup
+type PCIDeviceIOMMUGroupStatus struct {
+	// PCIDeviceNames lists the PCIDevice objects sharing this IOMMU group.
+	PCIDeviceNames []string `json:"pciDeviceNames,omitempty"`
+}
+
+// PCIDeviceIOMMUGroupName is the object name for the PCIDeviceIOMMUGroup
+// covering groupID on hostname.
+func PCIDeviceIOMMUGroupName(hostname, groupID string) string {
+	return fmt.Sprintf("%s-%s", hostname, groupID)
+}
+
+// BuildIOMMUGroups groups iommuGroups (PCI address -> IOMMU group ID, the
+// same map PCIDeviceStatus.Update consumes) into one PCIDeviceIOMMUGroup
+// per group, so a controller can create/update the cluster-scoped objects.
+// ValidatePCIDeviceClaim does not read these objects; it checks the
+// claimed device's Status.IOMMUGroupSiblings. Addresses that fail
+// to resolve to a PCIDevice name are skipped rather than failing the
+// whole build.
+func BuildIOMMUGroups(hostname string, iommuGroups map[string]int) []PCIDeviceIOMMUGroup {
+	namesByGroup := map[int][]string{}
+	for pciAddress, group := range iommuGroups {
+		name, err := PCIDeviceNameForHostname(pciAddress, hostname)
+		if err != nil {
+			continue
+		}
+		namesByGroup[group] = append(namesByGroup[group], name)
+	}
+
+	groups := make([]PCIDeviceIOMMUGroup, 0, len(namesByGroup))
+	for group, names := range namesByGroup {
+		sort.Strings(names)
+		groupID := strconv.Itoa(group)
+		groups = append(groups, PCIDeviceIOMMUGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: PCIDeviceIOMMUGroupName(hostname, groupID),
+			},
+			Spec: PCIDeviceIOMMUGroupSpec{
+				NodeName: hostname,
+				GroupID:  groupID,
+			},
+			Status: PCIDeviceIOMMUGroupStatus{
+				PCIDeviceNames: names,
+			},
+		})
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups
+}
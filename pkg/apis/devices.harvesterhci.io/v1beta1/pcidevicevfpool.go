@@ -0,0 +1,174 @@
+package v1beta1
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/jaypipes/ghw/pkg/pci"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/votdev/pcidevices/pkg/apis/devices.harvesterhci.io/v1beta1/address"
+)
+
+const (
+	// PCIDeviceParentAddress annotates a VF-typed PCIDevice with the PCI
+	// address of the physical function it was spawned from.
+	PCIDeviceParentAddress = "harvesterhci.io/parentAddress"
+)
+
+// sysfsPCIDevicesRoot is the base of the sysfs PCI device tree. It is a
+// var, rather than a constant baked into each path format string, so
+// tests can point it at a temp directory to fake sysfs without touching
+// the real host.
+var sysfsPCIDevicesRoot = "/sys/bus/pci/devices"
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+
+// PCIDeviceVFPool is the Schema for the pcidevicevfpools API. It groups the
+// virtual functions spawned from a single physical function so they can be
+// requested, trunked and tracked as one pool rather than as unrelated
+// PCIDevice objects.
+type PCIDeviceVFPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PCIDeviceVFPoolSpec   `json:"spec,omitempty"`
+	Status PCIDeviceVFPoolStatus `json:"status,omitempty"`
+}
+
+// PCIDeviceVFPoolSpec defines the desired state of PCIDeviceVFPool
+type PCIDeviceVFPoolSpec struct {
+	// ParentAddress is the canonical DBSF address of the physical
+	// function this pool's VFs are spawned from.
+	ParentAddress string `json:"parentAddress"`
+	// NumVFs is the number of virtual functions the reconciler should
+	// converge the parent's sriov_numvfs sysfs attribute to.
+	NumVFs int `json:"numVFs"`
+	// VLANTrunk lists the VLAN IDs trunked across every VF in the pool.
+	VLANTrunk []int `json:"vlanTrunk,omitempty"`
+}
+
+// PCIDeviceVFPoolStatus defines the observed state of PCIDeviceVFPool
+type PCIDeviceVFPoolStatus struct {
+	// AllocatedVFs lists the PCIDevice names of the VFs currently
+	// spawned from ParentAddress.
+	AllocatedVFs []string `json:"allocatedVFs,omitempty"`
+}
+
+// ParentAddressForVF returns the PCI address of the physical function that
+// owns the virtual function at address, by resolving its sysfs "physfn"
+// symlink. ok is false when address is not a VF (the link does not exist).
+func ParentAddressForVF(pciAddress string) (parent string, ok bool) {
+	target, err := os.Readlink(filepath.Join(sysfsPCIDevicesRoot, pciAddress, "physfn"))
+	if err != nil {
+		return "", false
+	}
+	return filepath.Base(target), true
+}
+
+// ListVFAddresses returns the canonical DBSF addresses of the virtual
+// functions currently spawned from the physical function at parentAddress,
+// resolved from its sysfs "virtfnN" symlinks, in ascending VF index order.
+func ListVFAddresses(parentAddress string) ([]string, error) {
+	base := filepath.Join(sysfsPCIDevicesRoot, parentAddress)
+	entries, err := os.ReadDir(base)
+	if err != nil {
+		return nil, fmt.Errorf("listing VFs for %s: %w", parentAddress, err)
+	}
+	type indexedVF struct {
+		index   int
+		address string
+	}
+	var vfs []indexedVF
+	for _, entry := range entries {
+		var index int
+		if n, err := fmt.Sscanf(entry.Name(), "virtfn%d", &index); err != nil || n != 1 {
+			continue
+		}
+		target, err := os.Readlink(filepath.Join(base, entry.Name()))
+		if err != nil {
+			continue
+		}
+		parsed, err := address.FromString(filepath.Base(target))
+		if err != nil {
+			continue
+		}
+		vfs = append(vfs, indexedVF{index: index, address: parsed.String()})
+	}
+	sort.Slice(vfs, func(i, j int) bool { return vfs[i].index < vfs[j].index })
+	addresses := make([]string, len(vfs))
+	for i, vf := range vfs {
+		addresses[i] = vf.address
+	}
+	return addresses, nil
+}
+
+// NetDeviceForAddress returns the network interface name bound to the PCI
+// device at address, e.g. "eth0", by reading its sysfs "net" directory.
+// It is used to apply VF VLAN configuration, which iproute2 addresses by
+// net device and VF index rather than by PCI address.
+func NetDeviceForAddress(pciAddress string) (string, error) {
+	entries, err := os.ReadDir(filepath.Join(sysfsPCIDevicesRoot, pciAddress, "net"))
+	if err != nil {
+		return "", fmt.Errorf("resolving net device for %s: %w", pciAddress, err)
+	}
+	if len(entries) == 0 {
+		return "", fmt.Errorf("no net device bound to %s", pciAddress)
+	}
+	return entries[0].Name(), nil
+}
+
+// BuildPCIDevicesForHostname builds a PCIDevice for every scanned device,
+// resolving each virtual function's parent physical function via
+// ParentAddressForVF so VF-typed devices back-reference the already-built
+// PF PCIDevice through NewPCIDeviceForHostname's parent argument. iommuGroups
+// is the PCI-address-to-IOMMU-group map used to populate Status.IOMMUGroup
+// and Status.IOMMUGroupSiblings. excludeTopology is consulted per device
+// (see ExcludeTopologyFromClaims) to decide whether Status.NUMANode is
+// reported for that address.
+func BuildPCIDevicesForHostname(devices []*pci.Device, hostname string, classConfig *PCIDeviceClassConfig, iommuGroups map[string]int, excludeTopology func(address string) bool) ([]PCIDevice, error) {
+	byAddress := make(map[string]*pci.Device, len(devices))
+	for _, dev := range devices {
+		byAddress[dev.Address] = dev
+	}
+
+	built := make(map[string]PCIDevice, len(devices))
+	var buildDevice func(dev *pci.Device) (PCIDevice, error)
+	buildDevice = func(dev *pci.Device) (PCIDevice, error) {
+		if existing, ok := built[dev.Address]; ok {
+			return existing, nil
+		}
+		var parent *PCIDevice
+		if parentAddr, isVF := ParentAddressForVF(dev.Address); isVF {
+			if parentDev, known := byAddress[parentAddr]; known {
+				parentDevice, err := buildDevice(parentDev)
+				if err != nil {
+					return PCIDevice{}, err
+				}
+				parent = &parentDevice
+			}
+		}
+		exclude := excludeTopology != nil && excludeTopology(dev.Address)
+		pciDevice, err := NewPCIDeviceForHostname(dev, hostname, classConfig, parent, iommuGroups, exclude)
+		if err != nil {
+			return PCIDevice{}, err
+		}
+		built[dev.Address] = pciDevice
+		return pciDevice, nil
+	}
+
+	result := make([]PCIDevice, 0, len(devices))
+	for _, dev := range devices {
+		pciDevice, err := buildDevice(dev)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, pciDevice)
+	}
+	return result, nil
+}
@@ -0,0 +1,107 @@
+package v1beta1
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// withFakeSysfs points sysfsPCIDevicesRoot at a temp directory for the
+// duration of the test, restoring the real path on cleanup.
+func withFakeSysfs(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	old := sysfsPCIDevicesRoot
+	sysfsPCIDevicesRoot = root
+	t.Cleanup(func() { sysfsPCIDevicesRoot = old })
+	return root
+}
+
+func mustMkdirAll(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(path, 0755); err != nil {
+		t.Fatalf("MkdirAll(%q): %v", path, err)
+	}
+}
+
+func mustSymlink(t *testing.T, oldname, newname string) {
+	t.Helper()
+	if err := os.Symlink(oldname, newname); err != nil {
+		t.Fatalf("Symlink(%q, %q): %v", oldname, newname, err)
+	}
+}
+
+func TestParentAddressForVF(t *testing.T) {
+	root := withFakeSysfs(t)
+	mustMkdirAll(t, filepath.Join(root, "0000:00:1f.0"))
+	mustMkdirAll(t, filepath.Join(root, "0000:3b:00.0"))
+	mustSymlink(t, "../0000:00:1f.0", filepath.Join(root, "0000:3b:00.0", "physfn"))
+
+	parent, ok := ParentAddressForVF("0000:3b:00.0")
+	if !ok {
+		t.Fatalf("ParentAddressForVF() ok = false, want true")
+	}
+	if parent != "0000:00:1f.0" {
+		t.Fatalf("ParentAddressForVF() = %q, want %q", parent, "0000:00:1f.0")
+	}
+
+	if _, ok := ParentAddressForVF("0000:00:1f.0"); ok {
+		t.Fatalf("ParentAddressForVF() on a PF (no physfn link) ok = true, want false")
+	}
+}
+
+func TestListVFAddresses(t *testing.T) {
+	root := withFakeSysfs(t)
+	pf := filepath.Join(root, "0000:00:1f.0")
+	mustMkdirAll(t, pf)
+	mustMkdirAll(t, filepath.Join(root, "0000:3b:00.1"))
+	mustMkdirAll(t, filepath.Join(root, "0000:3b:00.0"))
+	// Registered out of index order to verify the result is sorted by index.
+	mustSymlink(t, "../0000:3b:00.1", filepath.Join(pf, "virtfn1"))
+	mustSymlink(t, "../0000:3b:00.0", filepath.Join(pf, "virtfn0"))
+	// A non-virtfn entry must be ignored.
+	mustMkdirAll(t, filepath.Join(pf, "subsystem"))
+
+	addresses, err := ListVFAddresses("0000:00:1f.0")
+	if err != nil {
+		t.Fatalf("ListVFAddresses() returned error: %v", err)
+	}
+	want := []string{"0000:3b:00.0", "0000:3b:00.1"}
+	if len(addresses) != len(want) {
+		t.Fatalf("ListVFAddresses() = %v, want %v", addresses, want)
+	}
+	for i := range want {
+		if addresses[i] != want[i] {
+			t.Fatalf("ListVFAddresses() = %v, want %v", addresses, want)
+		}
+	}
+}
+
+func TestListVFAddressesNoSuchDevice(t *testing.T) {
+	withFakeSysfs(t)
+	if _, err := ListVFAddresses("0000:00:1f.0"); err == nil {
+		t.Fatalf("ListVFAddresses() = nil error, want error for missing sysfs entry")
+	}
+}
+
+func TestNetDeviceForAddress(t *testing.T) {
+	root := withFakeSysfs(t)
+	mustMkdirAll(t, filepath.Join(root, "0000:3b:00.0", "net", "eth0"))
+
+	netDevice, err := NetDeviceForAddress("0000:3b:00.0")
+	if err != nil {
+		t.Fatalf("NetDeviceForAddress() returned error: %v", err)
+	}
+	if netDevice != "eth0" {
+		t.Fatalf("NetDeviceForAddress() = %q, want %q", netDevice, "eth0")
+	}
+}
+
+func TestNetDeviceForAddressNoNetDevice(t *testing.T) {
+	root := withFakeSysfs(t)
+	mustMkdirAll(t, filepath.Join(root, "0000:3b:00.0", "net"))
+
+	if _, err := NetDeviceForAddress("0000:3b:00.0"); err == nil {
+		t.Fatalf("NetDeviceForAddress() = nil error, want error for empty net directory")
+	}
+}
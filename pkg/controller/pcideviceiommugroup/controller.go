@@ -0,0 +1,35 @@
+// Package pcideviceiommugroup reconciles the PCIDeviceIOMMUGroup objects
+// for a host from the IOMMU groups reported by the latest PCI scan.
+package pcideviceiommugroup
+
+import (
+	"fmt"
+
+	v1beta1 "github.com/votdev/pcidevices/pkg/apis/devices.harvesterhci.io/v1beta1"
+)
+
+// Applier persists one desired PCIDeviceIOMMUGroup, e.g. by calling a
+// generated clientset's Create-or-Update against the apiserver.
+type Applier interface {
+	Apply(group *v1beta1.PCIDeviceIOMMUGroup) error
+}
+
+// Reconciler converges the cluster's PCIDeviceIOMMUGroup objects for a
+// host to the IOMMU groups reported by the latest PCI scan.
+type Reconciler struct {
+	Hostname string
+	Applier  Applier
+}
+
+// Reconcile builds one PCIDeviceIOMMUGroup per IOMMU group present in
+// iommuGroups (as already consumed by PCIDeviceStatus.Update) and applies
+// each through r.Applier.
+func (r *Reconciler) Reconcile(iommuGroups map[string]int) error {
+	for _, group := range v1beta1.BuildIOMMUGroups(r.Hostname, iommuGroups) {
+		group := group
+		if err := r.Applier.Apply(&group); err != nil {
+			return fmt.Errorf("applying PCIDeviceIOMMUGroup %s: %w", group.Name, err)
+		}
+	}
+	return nil
+}
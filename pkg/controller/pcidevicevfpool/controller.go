@@ -0,0 +1,131 @@
+// Package pcidevicevfpool reconciles PCIDeviceVFPool objects: it drives the
+// sriov_numvfs sysfs attribute of the pool's parent physical function,
+// records the resulting VFs in Status.AllocatedVFs, and applies the
+// requested VLAN trunk to each of them.
+package pcidevicevfpool
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	v1beta1 "github.com/votdev/pcidevices/pkg/apis/devices.harvesterhci.io/v1beta1"
+)
+
+const sriovNumVFsPathFormat = "/sys/bus/pci/devices/%s/sriov_numvfs"
+
+// VLANSetter applies a VLAN trunk to one virtual function, identified by
+// its parent's net device name and VF index.
+type VLANSetter interface {
+	SetVLANTrunk(netDevice string, vfIndex int, vlans []int) error
+}
+
+// execVLANSetter drives VLAN trunk configuration through `ip link set vf
+// vlan`, the standard SR-IOV VLAN configuration path.
+type execVLANSetter struct{}
+
+// SetVLANTrunk configures vlans on the given VF. Each `ip link set ... vf
+// N vlan V` invocation replaces, rather than adds to, the VF's configured
+// VLANs, so a multi-VLAN trunk must be set in a single invocation with one
+// "vlan V" clause per entry (iproute2/driver support for more than one
+// clause is what makes trunking possible at all; a driver that only
+// accepts one clause simply ends up with the last VLAN applied).
+func (execVLANSetter) SetVLANTrunk(netDevice string, vfIndex int, vlans []int) error {
+	if len(vlans) == 0 {
+		return nil
+	}
+	args := []string{"link", "set", "dev", netDevice, "vf", strconv.Itoa(vfIndex)}
+	for _, vlan := range vlans {
+		args = append(args, "vlan", strconv.Itoa(vlan))
+	}
+	cmd := exec.Command("ip", args...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("setting vlan trunk %v on %s vf %d: %w: %s", vlans, netDevice, vfIndex, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// Reconciler converges a PCIDeviceVFPool's parent PF to the requested
+// number of virtual functions, its VLAN trunk, and reports the resulting
+// VFs in Status.AllocatedVFs.
+type Reconciler struct {
+	// Hostname names the node whose PCIDevice objects AllocatedVFs
+	// entries are resolved against, matching PCIDeviceNameForHostname.
+	Hostname string
+	// VLANSetter applies pool.Spec.VLANTrunk to each VF. Defaults to
+	// execVLANSetter (the real `ip link` implementation) when nil.
+	VLANSetter VLANSetter
+}
+
+// Reconcile reads the parent PF's current sriov_numvfs and, if it differs
+// from pool.Spec.NumVFs, writes the requested count. The kernel requires
+// sriov_numvfs to be reset to 0 before it can be raised again, so a
+// reduction and a subsequent increase are both handled by this same
+// write-0-then-write-N sequence. It then resolves the resulting VFs into
+// pool.Status.AllocatedVFs and, if pool.Spec.VLANTrunk is set, applies it
+// to every VF.
+func (r *Reconciler) Reconcile(pool *v1beta1.PCIDeviceVFPool) error {
+	path := fmt.Sprintf(sriovNumVFsPathFormat, pool.Spec.ParentAddress)
+	current, err := readNumVFs(path)
+	if err != nil {
+		return fmt.Errorf("reading sriov_numvfs for %s: %w", pool.Spec.ParentAddress, err)
+	}
+	if current != pool.Spec.NumVFs {
+		if current != 0 {
+			if err := writeNumVFs(path, 0); err != nil {
+				return fmt.Errorf("resetting sriov_numvfs for %s: %w", pool.Spec.ParentAddress, err)
+			}
+		}
+		if pool.Spec.NumVFs != 0 {
+			if err := writeNumVFs(path, pool.Spec.NumVFs); err != nil {
+				return fmt.Errorf("setting sriov_numvfs to %d for %s: %w", pool.Spec.NumVFs, pool.Spec.ParentAddress, err)
+			}
+		}
+	}
+
+	vfAddresses, err := v1beta1.ListVFAddresses(pool.Spec.ParentAddress)
+	if err != nil {
+		return fmt.Errorf("resolving VFs for %s: %w", pool.Spec.ParentAddress, err)
+	}
+	allocated := make([]string, 0, len(vfAddresses))
+	for _, vfAddress := range vfAddresses {
+		name, err := v1beta1.PCIDeviceNameForHostname(vfAddress, r.Hostname)
+		if err != nil {
+			return fmt.Errorf("naming VF %s: %w", vfAddress, err)
+		}
+		allocated = append(allocated, name)
+	}
+	pool.Status.AllocatedVFs = allocated
+
+	if len(pool.Spec.VLANTrunk) == 0 || len(vfAddresses) == 0 {
+		return nil
+	}
+	netDevice, err := v1beta1.NetDeviceForAddress(pool.Spec.ParentAddress)
+	if err != nil {
+		return fmt.Errorf("applying VLAN trunk for %s: %w", pool.Spec.ParentAddress, err)
+	}
+	setter := r.VLANSetter
+	if setter == nil {
+		setter = execVLANSetter{}
+	}
+	for i := range vfAddresses {
+		if err := setter.SetVLANTrunk(netDevice, i, pool.Spec.VLANTrunk); err != nil {
+			return fmt.Errorf("applying VLAN trunk to %s vf %d: %w", pool.Spec.ParentAddress, i, err)
+		}
+	}
+	return nil
+}
+
+func readNumVFs(path string) (int, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(raw)))
+}
+
+func writeNumVFs(path string, n int) error {
+	return os.WriteFile(path, []byte(strconv.Itoa(n)), 0644)
+}
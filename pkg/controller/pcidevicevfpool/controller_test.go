@@ -0,0 +1,55 @@
+package pcidevicevfpool
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestReadNumVFs(t *testing.T) {
+	t.Run("parses the current value", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "sriov_numvfs")
+		if err := writeNumVFs(path, 4); err != nil {
+			t.Fatalf("writeNumVFs() returned error: %v", err)
+		}
+		got, err := readNumVFs(path)
+		if err != nil {
+			t.Fatalf("readNumVFs() returned error: %v", err)
+		}
+		if got != 4 {
+			t.Fatalf("readNumVFs() = %d, want 4", got)
+		}
+	})
+
+	t.Run("missing file returns an error", func(t *testing.T) {
+		if _, err := readNumVFs(filepath.Join(t.TempDir(), "sriov_numvfs")); err == nil {
+			t.Fatalf("readNumVFs() = nil error, want error for missing file")
+		}
+	})
+}
+
+func TestWriteNumVFs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sriov_numvfs")
+	if err := writeNumVFs(path, 8); err != nil {
+		t.Fatalf("writeNumVFs() returned error: %v", err)
+	}
+	got, err := readNumVFs(path)
+	if err != nil {
+		t.Fatalf("readNumVFs() returned error: %v", err)
+	}
+	if got != 8 {
+		t.Fatalf("readNumVFs() = %d, want 8", got)
+	}
+
+	// Writing 0 (the reset step before raising sriov_numvfs again) must
+	// round-trip too.
+	if err := writeNumVFs(path, 0); err != nil {
+		t.Fatalf("writeNumVFs(0) returned error: %v", err)
+	}
+	got, err = readNumVFs(path)
+	if err != nil {
+		t.Fatalf("readNumVFs() returned error: %v", err)
+	}
+	if got != 0 {
+		t.Fatalf("readNumVFs() = %d, want 0", got)
+	}
+}
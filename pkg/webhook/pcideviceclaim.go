@@ -0,0 +1,34 @@
+// Package webhook implements admission validation for this module's CRDs.
+package webhook
+
+import (
+	"fmt"
+
+	v1beta1 "github.com/votdev/pcidevices/pkg/apis/devices.harvesterhci.io/v1beta1"
+)
+
+// ValidatePCIDeviceClaim enforces that VFIO passthrough only ever binds a
+// complete IOMMU group. A claim on device is rejected unless every sibling
+// listed in device.Status.IOMMUGroupSiblings is either already claimed by
+// the same consumer (siblingClaims, keyed by PCI address) or the claim
+// explicitly opts out via Spec.IOMMUGroupIsolated.
+func ValidatePCIDeviceClaim(claim *v1beta1.PCIDeviceClaim, device *v1beta1.PCIDevice, siblingClaims map[string]*v1beta1.PCIDeviceClaim) error {
+	if claim == nil || device == nil {
+		return fmt.Errorf("validating PCIDeviceClaim: claim and device must not be nil")
+	}
+	if claim.Spec.IOMMUGroupIsolated {
+		return nil
+	}
+	for _, sibling := range device.Status.IOMMUGroupSiblings {
+		siblingClaim, claimed := siblingClaims[sibling]
+		if !claimed {
+			return fmt.Errorf("device %s shares IOMMU group %s with unclaimed device %s: "+
+				"claim every sibling or set iommuGroupIsolated", device.Status.Address, device.Status.IOMMUGroup, sibling)
+		}
+		if siblingClaim.Spec.UserName != claim.Spec.UserName {
+			return fmt.Errorf("device %s shares IOMMU group %s with %s, claimed by a different consumer (%s)",
+				device.Status.Address, device.Status.IOMMUGroup, sibling, siblingClaim.Spec.UserName)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package webhook
+
+import (
+	"testing"
+
+	v1beta1 "github.com/votdev/pcidevices/pkg/apis/devices.harvesterhci.io/v1beta1"
+)
+
+func TestValidatePCIDeviceClaim(t *testing.T) {
+	device := &v1beta1.PCIDevice{
+		Status: v1beta1.PCIDeviceStatus{
+			Address:            "0000:3b:00.0",
+			IOMMUGroup:         "42",
+			IOMMUGroupSiblings: []string{"0000:3b:00.1"},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		claim         *v1beta1.PCIDeviceClaim
+		device        *v1beta1.PCIDevice
+		siblingClaims map[string]*v1beta1.PCIDeviceClaim
+		wantErr       bool
+	}{
+		{
+			name:    "nil claim is rejected",
+			claim:   nil,
+			device:  device,
+			wantErr: true,
+		},
+		{
+			name:    "nil device is rejected",
+			claim:   &v1beta1.PCIDeviceClaim{},
+			device:  nil,
+			wantErr: true,
+		},
+		{
+			name:    "isolated claim bypasses sibling check",
+			claim:   &v1beta1.PCIDeviceClaim{Spec: v1beta1.PCIDeviceClaimSpec{UserName: "alice", IOMMUGroupIsolated: true}},
+			device:  device,
+			wantErr: false,
+		},
+		{
+			name:          "unclaimed sibling is rejected",
+			claim:         &v1beta1.PCIDeviceClaim{Spec: v1beta1.PCIDeviceClaimSpec{UserName: "alice"}},
+			device:        device,
+			siblingClaims: map[string]*v1beta1.PCIDeviceClaim{},
+			wantErr:       true,
+		},
+		{
+			name:   "sibling claimed by a different consumer is rejected",
+			claim:  &v1beta1.PCIDeviceClaim{Spec: v1beta1.PCIDeviceClaimSpec{UserName: "alice"}},
+			device: device,
+			siblingClaims: map[string]*v1beta1.PCIDeviceClaim{
+				"0000:3b:00.1": {Spec: v1beta1.PCIDeviceClaimSpec{UserName: "bob"}},
+			},
+			wantErr: true,
+		},
+		{
+			name:   "sibling claimed by the same consumer is allowed",
+			claim:  &v1beta1.PCIDeviceClaim{Spec: v1beta1.PCIDeviceClaimSpec{UserName: "alice"}},
+			device: device,
+			siblingClaims: map[string]*v1beta1.PCIDeviceClaim{
+				"0000:3b:00.1": {Spec: v1beta1.PCIDeviceClaimSpec{UserName: "alice"}},
+			},
+			wantErr: false,
+		},
+		{
+			name:          "device with no siblings is allowed regardless of claims",
+			claim:         &v1beta1.PCIDeviceClaim{Spec: v1beta1.PCIDeviceClaimSpec{UserName: "alice"}},
+			device:        &v1beta1.PCIDevice{Status: v1beta1.PCIDeviceStatus{Address: "0000:3b:00.0"}},
+			siblingClaims: map[string]*v1beta1.PCIDeviceClaim{},
+			wantErr:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePCIDeviceClaim(tt.claim, tt.device, tt.siblingClaims)
+			if tt.wantErr && err == nil {
+				t.Fatalf("ValidatePCIDeviceClaim() = nil, want error")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("ValidatePCIDeviceClaim() returned unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1beta1 "github.com/votdev/pcidevices/pkg/apis/devices.harvesterhci.io/v1beta1"
+)
+
+// ClaimStore resolves the data ValidatePCIDeviceClaim needs to judge an
+// incoming PCIDeviceClaim: the device being claimed, and the existing
+// claims on its IOMMU group siblings, keyed by PCI address.
+type ClaimStore interface {
+	GetPCIDevice(address string) (*v1beta1.PCIDevice, error)
+	ListPCIDeviceClaimsByAddress() (map[string]*v1beta1.PCIDeviceClaim, error)
+}
+
+// PCIDeviceClaimValidator serves the PCIDeviceClaim validating admission
+// webhook endpoint: it decodes an AdmissionReview, runs
+// ValidatePCIDeviceClaim against the claimed device's IOMMU group
+// siblings, and responds with the admission verdict.
+type PCIDeviceClaimValidator struct {
+	Store ClaimStore
+}
+
+func (v *PCIDeviceClaimValidator) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	review := &admissionv1.AdmissionReview{}
+	if err := json.NewDecoder(req.Body).Decode(review); err != nil {
+		http.Error(rw, fmt.Sprintf("decoding admission review: %v", err), http.StatusBadRequest)
+		return
+	}
+	response := v.review(review)
+	rw.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(rw).Encode(&admissionv1.AdmissionReview{
+		TypeMeta: review.TypeMeta,
+		Response: response,
+	})
+}
+
+func (v *PCIDeviceClaimValidator) review(review *admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
+	if review.Request == nil {
+		return denied("", fmt.Errorf("admission review has no request"))
+	}
+	uid := review.Request.UID
+
+	claim := &v1beta1.PCIDeviceClaim{}
+	if err := json.Unmarshal(review.Request.Object.Raw, claim); err != nil {
+		return denied(uid, fmt.Errorf("decoding PCIDeviceClaim: %w", err))
+	}
+
+	device, err := v.Store.GetPCIDevice(claim.Spec.Address)
+	if err != nil {
+		return denied(uid, fmt.Errorf("looking up device %s: %w", claim.Spec.Address, err))
+	}
+
+	siblingClaims, err := v.Store.ListPCIDeviceClaimsByAddress()
+	if err != nil {
+		return denied(uid, fmt.Errorf("listing PCIDeviceClaims: %w", err))
+	}
+
+	if err := ValidatePCIDeviceClaim(claim, device, siblingClaims); err != nil {
+		return denied(uid, err)
+	}
+	return &admissionv1.AdmissionResponse{UID: uid, Allowed: true}
+}
+
+func denied(uid types.UID, err error) *admissionv1.AdmissionResponse {
+	return &admissionv1.AdmissionResponse{
+		UID:     uid,
+		Allowed: false,
+		Result: &metav1.Status{
+			Message: err.Error(),
+		},
+	}
+}